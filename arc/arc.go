@@ -0,0 +1,333 @@
+// Package arc implements an Adaptive Replacement Cache (ARC), a scan
+// resistant alternative to the approximate-LRU cache in the parent package.
+package arc
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// entry is used to hold a value in T1/T2.  B1/B2 only ever hold keys (the
+// values have already been evicted), so their list elements store a bare K.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// ARCCache is a thread-safe fixed size Adaptive Replacement Cache (ARC).
+// ARC tracks both recency (T1) and frequency (T2), plus ghost lists (B1/B2)
+// of recently evicted keys, and adapts the split between T1 and T2 based on
+// which ghost list is taking hits.  This makes it scan resistant in cases
+// where the approximate-LRU cache would be thrashed by a one-off full scan.
+type ARCCache[K comparable, V any] struct {
+	lock sync.Mutex
+
+	size int // c: target total number of cached (non-ghost) entries
+	p    int // target size for T1
+
+	t1 *list.List // recent cache entries
+	t2 *list.List // frequent cache entries
+	b1 *list.List // ghost entries recently evicted from T1
+	b2 *list.List // ghost entries recently evicted from T2
+
+	t1Items map[K]*list.Element
+	t2Items map[K]*list.Element
+	b1Items map[K]*list.Element
+	b2Items map[K]*list.Element
+}
+
+// NewARC creates an ARC of the given size.
+func NewARC[K comparable, V any](size int) (*ARCCache[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	c := &ARCCache[K, V]{
+		size:    size,
+		t1:      list.New(),
+		t2:      list.New(),
+		b1:      list.New(),
+		b2:      list.New(),
+		t1Items: make(map[K]*list.Element),
+		t2Items: make(map[K]*list.Element),
+		b1Items: make(map[K]*list.Element),
+		b2Items: make(map[K]*list.Element),
+	}
+	return c, nil
+}
+
+// Get looks up a key's value from the cache.
+func (c *ARCCache[K, V]) Get(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if el, ok := c.t1Items[key]; ok {
+		ent := el.Value.(*entry[K, V])
+		c.t1.Remove(el)
+		delete(c.t1Items, key)
+		c.t2Items[key] = c.t2.PushFront(ent)
+		return ent.value, true
+	}
+
+	if el, ok := c.t2Items[key]; ok {
+		c.t2.MoveToFront(el)
+		return el.Value.(*entry[K, V]).value, true
+	}
+
+	return value, false
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ARCCache[K, V]) Add(key K, value V) (evicted bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if el, ok := c.t1Items[key]; ok {
+		c.t1.Remove(el)
+		delete(c.t1Items, key)
+		c.t2Items[key] = c.t2.PushFront(&entry[K, V]{key, value})
+		return false
+	}
+
+	if el, ok := c.t2Items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.t2.MoveToFront(el)
+		return false
+	}
+
+	// key is a hit in B1: T1 is under-serving, grow p towards T1.
+	if el, ok := c.b1Items[key]; ok {
+		delta := 1
+		if b1Len, b2Len := c.b1.Len(), c.b2.Len(); b2Len > b1Len {
+			delta = b2Len / b1Len
+		}
+		c.p = minInt(c.p+delta, c.size)
+
+		evicted = c.replace(false)
+
+		c.b1.Remove(el)
+		delete(c.b1Items, key)
+
+		c.t2Items[key] = c.t2.PushFront(&entry[K, V]{key, value})
+		return evicted
+	}
+
+	// key is a hit in B2: T2 is under-serving, shrink p towards T2.
+	if el, ok := c.b2Items[key]; ok {
+		delta := 1
+		if b1Len, b2Len := c.b1.Len(), c.b2.Len(); b1Len > b2Len {
+			delta = b1Len / b2Len
+		}
+		if delta > c.p {
+			c.p = 0
+		} else {
+			c.p -= delta
+		}
+
+		evicted = c.replace(true)
+
+		c.b2.Remove(el)
+		delete(c.b2Items, key)
+
+		c.t2Items[key] = c.t2.PushFront(&entry[K, V]{key, value})
+		return evicted
+	}
+
+	// brand new key
+	switch {
+	case c.t1.Len()+c.b1.Len() == c.size:
+		if c.t1.Len() < c.size {
+			c.removeB1Oldest()
+			evicted = c.replace(false)
+		} else {
+			c.removeT1Oldest()
+			evicted = true
+		}
+	case c.t1.Len()+c.b1.Len()+c.t2.Len()+c.b2.Len() >= c.size:
+		if c.t1.Len()+c.b1.Len()+c.t2.Len()+c.b2.Len() == 2*c.size {
+			c.removeB2Oldest()
+		}
+		evicted = c.replace(false)
+	}
+
+	c.t1Items[key] = c.t1.PushFront(&entry[K, V]{key, value})
+
+	return evicted
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness
+// or frequency of the key.
+func (c *ARCCache[K, V]) Contains(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.t1Items[key]; ok {
+		return true
+	}
+	_, ok := c.t2Items[key]
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the recent-ness or frequency of the key.
+func (c *ARCCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if el, ok := c.t1Items[key]; ok {
+		return el.Value.(*entry[K, V]).value, true
+	}
+	if el, ok := c.t2Items[key]; ok {
+		return el.Value.(*entry[K, V]).value, true
+	}
+	return value, false
+}
+
+// Remove removes the provided key from the cache.
+func (c *ARCCache[K, V]) Remove(key K) (present bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if el, ok := c.t1Items[key]; ok {
+		c.t1.Remove(el)
+		delete(c.t1Items, key)
+		return true
+	}
+	if el, ok := c.t2Items[key]; ok {
+		c.t2.Remove(el)
+		delete(c.t2Items, key)
+		return true
+	}
+	if el, ok := c.b1Items[key]; ok {
+		c.b1.Remove(el)
+		delete(c.b1Items, key)
+		return true
+	}
+	if el, ok := c.b2Items[key]; ok {
+		c.b2.Remove(el)
+		delete(c.b2Items, key)
+		return true
+	}
+	return false
+}
+
+// Purge is used to completely clear the cache.
+func (c *ARCCache[K, V]) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.p = 0
+	c.t1.Init()
+	c.t2.Init()
+	c.b1.Init()
+	c.b2.Init()
+	c.t1Items = make(map[K]*list.Element)
+	c.t2Items = make(map[K]*list.Element)
+	c.b1Items = make(map[K]*list.Element)
+	c.b2Items = make(map[K]*list.Element)
+}
+
+// Len returns the number of items in the cache (T1 + T2; the ghost lists
+// B1/B2 hold no values and are not counted).
+func (c *ARCCache[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Resize changes the cache size, evicting entries if it is shrinking.
+func (c *ARCCache[K, V]) Resize(size int) (evicted int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// unlike NewARC, which rejects a non-positive size, a negative size is
+	// clamped to zero (an empty cache) rather than looping forever trying to
+	// shrink past zero.
+	if size < 0 {
+		size = 0
+	}
+
+	for c.t1.Len()+c.t2.Len() > size {
+		c.replace(false)
+		evicted++
+	}
+	for c.b1.Len()+c.b2.Len() > size {
+		if c.b1.Len() > c.b2.Len() {
+			c.removeB1Oldest()
+		} else {
+			c.removeB2Oldest()
+		}
+	}
+
+	c.size = size
+	if c.p > size {
+		c.p = size
+	}
+
+	return evicted
+}
+
+// replace evicts the LRU entry of T1 into B1, or the LRU entry of T2 into
+// B2, depending on whether T1 exceeds the target size p.  b2ContainsKey is
+// true when the Add that triggered this replace was itself a hit in B2, per
+// the ARC paper's tie-breaking rule.
+func (c *ARCCache[K, V]) replace(b2ContainsKey bool) (evicted bool) {
+	t1Len := c.t1.Len()
+	if t1Len > 0 && (t1Len > c.p || (t1Len == c.p && b2ContainsKey)) {
+		el := c.t1.Back()
+		if el == nil {
+			return false
+		}
+		ent := el.Value.(*entry[K, V])
+		c.t1.Remove(el)
+		delete(c.t1Items, ent.key)
+		c.b1Items[ent.key] = c.b1.PushFront(ent.key)
+		return true
+	}
+
+	el := c.t2.Back()
+	if el == nil {
+		return false
+	}
+	ent := el.Value.(*entry[K, V])
+	c.t2.Remove(el)
+	delete(c.t2Items, ent.key)
+	c.b2Items[ent.key] = c.b2.PushFront(ent.key)
+	return true
+}
+
+func (c *ARCCache[K, V]) removeT1Oldest() {
+	el := c.t1.Back()
+	if el == nil {
+		return
+	}
+	ent := el.Value.(*entry[K, V])
+	c.t1.Remove(el)
+	delete(c.t1Items, ent.key)
+}
+
+func (c *ARCCache[K, V]) removeB1Oldest() {
+	el := c.b1.Back()
+	if el == nil {
+		return
+	}
+	c.b1.Remove(el)
+	delete(c.b1Items, el.Value.(K))
+}
+
+func (c *ARCCache[K, V]) removeB2Oldest() {
+	el := c.b2.Back()
+	if el == nil {
+		return
+	}
+	c.b2.Remove(el)
+	delete(c.b2Items, el.Value.(K))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
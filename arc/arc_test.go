@@ -0,0 +1,119 @@
+package arc
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewARC(t *testing.T) {
+	l, err := NewARC[string, int](128)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < 256; i++ {
+		l.Add(strconv.Itoa(i), i)
+	}
+	if l.Len() != 128 {
+		panic(fmt.Sprintf("bad len: %v", l.Len()))
+	}
+	if v, ok := l.Get("200"); ok {
+		_ = v // use v
+	}
+}
+
+// TestARCPromotionSurvivesEviction exercises the T1->T2 promotion path: a
+// key touched via Get should move to T2, and replace() should keep
+// preferring T1 evictions (since p starts at 0) so the promoted key
+// survives churn that evicts everything still sitting in T1.
+func TestARCPromotionSurvivesEviction(t *testing.T) {
+	c, err := NewARC[string, int](3)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected \"a\" to be present")
+	}
+
+	c.Add("d", 4)
+	c.Add("e", 5)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected promoted key \"a\" to survive eviction of T1 entries")
+	}
+	if c.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", c.Len())
+	}
+}
+
+// TestARCGhostHitInB1GrowsP exercises the adaptive part of ARC: a hit on a
+// key that is only a ghost in B1 must grow p (biasing future replace()
+// calls towards T2) and promote that key directly into T2.
+func TestARCGhostHitInB1GrowsP(t *testing.T) {
+	c, err := NewARC[string, int](4)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	c.Add("d", 4)
+
+	if _, ok := c.Get("a"); !ok { // promote "a" into T2, freeing a T1 slot
+		t.Fatalf("expected \"a\" to be present")
+	}
+
+	c.Add("e", 5) // T1 fills up again; its LRU ("b") is pushed into B1
+
+	if _, ok := c.b1Items["b"]; !ok {
+		t.Fatalf("expected \"b\" to have been evicted into B1")
+	}
+	if c.p != 0 {
+		t.Fatalf("p = %d, want 0 before any ghost hit", c.p)
+	}
+
+	c.Add("b", 20) // a B1 ghost hit should grow p and promote "b" into T2
+
+	if c.p == 0 {
+		t.Fatalf("expected p to grow after a B1 hit, still 0")
+	}
+	if _, ok := c.t2Items["b"]; !ok {
+		t.Fatalf("expected \"b\" to be promoted into T2 after the B1 hit")
+	}
+	if _, ok := c.b1Items["b"]; ok {
+		t.Fatalf("expected \"b\" to be removed from B1 after the hit")
+	}
+}
+
+// TestARCResizeNonPositiveDoesNotHang guards against Resize looping forever
+// when asked to shrink to a non-positive size, which replace() can never
+// satisfy once T1 and T2 are both empty.
+func TestARCResizeNonPositiveDoesNotHang(t *testing.T) {
+	c, err := NewARC[string, int](2)
+	if err != nil {
+		t.Fatalf("NewARC: %v", err)
+	}
+	c.Add("a", 1)
+	c.Add("b", 2)
+
+	done := make(chan int, 1)
+	go func() {
+		done <- c.Resize(-1)
+	}()
+
+	select {
+	case <-done:
+		if c.Len() != 0 {
+			t.Fatalf("Len() = %d, want 0 after resizing to a non-positive size", c.Len())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Resize(-1) did not return within 2s; likely looping forever")
+	}
+}
@@ -0,0 +1,93 @@
+package lru
+
+import "sync"
+
+// call represents an in-flight or just-completed loader invocation for a
+// single key, shared by every concurrent GetOrLoad call for that key.
+type call[V any] struct {
+	wg       sync.WaitGroup
+	value    V
+	err      error
+	dups     int
+	shared   bool
+	panicVal any
+}
+
+// GetOrLoad returns the cached value for key if present, otherwise calls
+// loader to produce it and inserts the result into the cache.  Concurrent
+// misses for the same key are coalesced: only one of them actually calls
+// loader, and the rest block until it completes and share its result. shared
+// reports whether the returned value was the result of such a coalesced
+// call, for both the caller that ran loader and the ones that waited on it.
+// If loader panics, the panic is recovered, the inflight entry is cleaned
+// up, and the same panic is re-raised in this and every waiting goroutine,
+// mirroring golang.org/x/sync/singleflight.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (value V, err error, shared bool) {
+	c.lock.Lock()
+	if v, ok := c.lru.Get(key); ok {
+		c.lock.Unlock()
+		c.stats.recordLookup(true)
+		return v, nil, false
+	}
+	c.stats.recordLookup(false)
+
+	if ci, ok := c.inflight[key]; ok {
+		ci.dups++
+		c.lock.Unlock()
+
+		ci.wg.Wait()
+		if ci.panicVal != nil {
+			panic(ci.panicVal)
+		}
+		return ci.value, ci.err, true
+	}
+
+	ci := new(call[V])
+	ci.wg.Add(1)
+	if c.inflight == nil {
+		c.inflight = make(map[K]*call[V])
+	}
+	c.inflight[key] = ci
+	c.lock.Unlock()
+
+	c.runLoader(ci, key, loader)
+
+	if ci.panicVal != nil {
+		panic(ci.panicVal)
+	}
+
+	return ci.value, ci.err, ci.shared
+}
+
+// runLoader calls loader and records its outcome on ci.  If loader panics,
+// the panic is recovered here just long enough to clean up the inflight
+// entry and release any waiters, and is then re-raised so it still
+// propagates to the caller as if loader had never been coalesced.
+func (c *Cache[K, V]) runLoader(ci *call[V], key K, loader func(K) (V, error)) {
+	defer func() {
+		p := recover()
+		if p != nil {
+			ci.panicVal = p
+		}
+
+		c.lock.Lock()
+		delete(c.inflight, key)
+		if p == nil && ci.err == nil {
+			c.lru.Add(key, ci.value)
+		}
+		ci.shared = ci.dups > 0
+		c.lock.Unlock()
+
+		if p == nil && ci.err == nil {
+			c.stats.recordInsert()
+		}
+
+		ci.wg.Done()
+
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	ci.value, ci.err = loader(key)
+}
@@ -0,0 +1,82 @@
+package lru
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	l := New[string, int](128)
+
+	var calls int64
+	loader := func(key string) (int, error) {
+		atomic.AddInt64(&calls, 1)
+		return len(key), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err, _ := l.GetOrLoad("hello", loader); err != nil || v != len("hello") {
+				t.Errorf("GetOrLoad: got (%v, %v), want (%v, nil)", v, err, len("hello"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("loader called %d times, want 1", calls)
+	}
+}
+
+// TestGetOrLoadPanicCleansUp exercises what happens when loader panics: the
+// panic must propagate to the caller (and to any waiter coalesced onto the
+// same call), and the inflight entry must not be left wedged -- a later
+// GetOrLoad for the same key should run loader again rather than hang.
+func TestGetOrLoadPanicCleansUp(t *testing.T) {
+	l := New[string, int](128)
+
+	var waiterDone sync.WaitGroup
+	waiterDone.Add(1)
+	var waiterPanicked bool
+
+	var release sync.WaitGroup
+	release.Add(1)
+
+	go func() {
+		defer waiterDone.Done()
+		defer func() {
+			if recover() != nil {
+				waiterPanicked = true
+			}
+		}()
+		release.Wait()
+		l.GetOrLoad("boom", func(string) (int, error) {
+			panic("unreachable: the other goroutine already owns this call")
+		})
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected GetOrLoad to re-panic")
+			}
+		}()
+		l.GetOrLoad("boom", func(string) (int, error) {
+			release.Done() // let the waiter join this call before it panics
+			panic("loader exploded")
+		})
+	}()
+
+	waiterDone.Wait()
+	if !waiterPanicked {
+		t.Fatalf("expected the coalesced waiter to observe the panic too")
+	}
+
+	if v, err, shared := l.GetOrLoad("boom", func(string) (int, error) { return 42, nil }); err != nil || v != 42 || shared {
+		t.Fatalf("GetOrLoad after a panic: got (%v, %v, %v), want (42, nil, false)", v, err, shared)
+	}
+}
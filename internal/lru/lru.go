@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"math/rand"
+	"time"
 
 	"golang.org/x/exp/slices"
 )
@@ -20,8 +21,21 @@ func newRand() *rand.Rand {
 	return rand.New(rand.NewSource(int64(seed)))
 }
 
+// EvictReason indicates why an entry was removed from the cache.
+type EvictReason int
+
+const (
+	// EvictReasonCapacity indicates the entry was evicted to make room for a
+	// new one.
+	EvictReasonCapacity EvictReason = iota
+	// EvictReasonExpired indicates the entry's TTL had elapsed.
+	EvictReasonExpired
+	// EvictReasonManual indicates the entry was removed via Remove or Purge.
+	EvictReasonManual
+)
+
 // EvictCallback is used to get a callback when a cache entry is evicted
-type EvictCallback[K comparable, V any] func(key K, value V)
+type EvictCallback[K comparable, V any] func(key K, value V, reason EvictReason)
 
 // LRUStructSize is the size of the LRU struct -- there is a unit test to ensure
 // this const matches the size measured with `unsafe.Sizeof`.
@@ -33,12 +47,13 @@ const LRUStructSize = 104
 // than a linked list encoding a strict LRU relationship, we approximate it by
 // comparing 8 random entries and evicting the oldest.
 type LRU[K comparable, V any] struct {
-	items   map[K]int
-	data    []entry[K, V]
-	counter int64
-	size    int64
-	rng     rand.Rand
-	onEvict EvictCallback[K, V]
+	items      map[K]int
+	data       []entry[K, V]
+	counter    int64
+	size       int64
+	rng        rand.Rand
+	onEvict    EvictCallback[K, V]
+	defaultTTL time.Duration
 }
 
 // randomProbes is the number of elements we consider for eviction at a time,
@@ -50,6 +65,14 @@ type entry[K comparable, V any] struct {
 	lastUsed int64
 	key      K
 	value    V
+	// expiresAt is the unix-nanos deadline after which the entry is treated
+	// as absent, or 0 if the entry has no expiry.
+	expiresAt int64
+}
+
+// isExpired reports whether ent had an expiry set and it has passed as of now.
+func isExpired[K comparable, V any](ent *entry[K, V], now int64) bool {
+	return ent.expiresAt != 0 && ent.expiresAt <= now
 }
 
 // NewLRU constructs an LRU of the given size.  Memory for the full capacity of the
@@ -69,6 +92,13 @@ func NewLRU[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*LRU[K,
 	return c, nil
 }
 
+// SetDefaultTTL sets the TTL applied to entries added via Add (AddWithTTL
+// always takes precedence for the entry it inserts). A zero duration means
+// entries added via Add never expire.
+func (c *LRU[K, V]) SetDefaultTTL(ttl time.Duration) {
+	c.defaultTTL = ttl
+}
+
 func (c *LRU[K, V]) getCounter() int64 {
 	// if someone initializes a LRU as `&simplelru.LRU` directly, c.counter will
 	// be initialized to zero.  increment it to 1 to avoid Problems (we use 0 as
@@ -88,7 +118,7 @@ func (c *LRU[K, V]) Purge() {
 	if c.onEvict != nil {
 		for k, i := range c.items {
 			if entry := &c.data[i]; entry.lastUsed > 0 {
-				c.onEvict(k, entry.value)
+				c.onEvict(k, entry.value, EvictReasonManual)
 			}
 		}
 	}
@@ -102,14 +132,35 @@ func (c *LRU[K, V]) shuffle() {
 	c.rng.Shuffle(len(c.data), c.swap)
 }
 
-// Add adds a value to the cache.  Returns true if an eviction occurred.
+// Add adds a value to the cache.  Returns true if an eviction occurred.  If a
+// default TTL has been configured, the entry expires after it.
 func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
+	var expiresAt int64
+	if c.defaultTTL > 0 {
+		expiresAt = time.Now().Add(c.defaultTTL).UnixNano()
+	}
+	return c.addWithExpiry(key, value, expiresAt)
+}
+
+// AddWithTTL adds a value to the cache that expires after ttl, overriding
+// any default TTL.  A zero ttl means the entry never expires.  Returns true
+// if an eviction occurred.
+func (c *LRU[K, V]) AddWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+	return c.addWithExpiry(key, value, expiresAt)
+}
+
+func (c *LRU[K, V]) addWithExpiry(key K, value V, expiresAt int64) (evicted bool) {
 	now := c.getCounter()
 	// Check for existing item
 	if i, ok := c.items[key]; ok {
 		entry := &c.data[i]
 		entry.lastUsed = now
 		entry.value = value
+		entry.expiresAt = expiresAt
 		return false
 	}
 
@@ -119,12 +170,12 @@ func (c *LRU[K, V]) Add(key K, value V) (evicted bool) {
 	}
 
 	// Add new item
-	ent := entry[K, V]{now, key, value}
+	ent := entry[K, V]{lastUsed: now, key: key, value: value, expiresAt: expiresAt}
 
 	if int64(len(c.data)) == c.size {
 		evicted = true
 		if i, ok := c.findOldest(); ok {
-			c.removeElement(i, c.data[i], false)
+			c.removeElement(i, c.data[i], false, EvictReasonCapacity)
 			c.data[i] = ent
 			c.items[ent.key] = i
 		} else {
@@ -165,7 +216,8 @@ func (c *LRU[K, V]) swap(i, j int) {
 	c.data[i], c.data[j] = c.data[j], c.data[i]
 }
 
-// Get looks up a key's value from the cache.
+// Get looks up a key's value from the cache.  An expired entry is treated as
+// absent and is lazily evicted.
 func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
 	if i, ok := c.items[key]; ok {
 		entry := &c.data[i]
@@ -174,6 +226,11 @@ func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
 			var d V
 			return d, false
 		}
+		if isExpired(entry, time.Now().UnixNano()) {
+			c.removeElement(i, *entry, true, EvictReasonExpired)
+			var d V
+			return d, false
+		}
 		entry.lastUsed = c.getCounter()
 		return entry.value, true
 	}
@@ -181,17 +238,30 @@ func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
 }
 
 // Contains checks if a key is in the cache, without updating the recent-ness
-// or deleting it for being stale.
+// of the key.  An expired entry is treated as absent and is lazily evicted.
 func (c *LRU[K, V]) Contains(key K) (ok bool) {
-	_, ok = c.items[key]
-	return ok
+	i, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	if entry := &c.data[i]; isExpired(entry, time.Now().UnixNano()) {
+		c.removeElement(i, *entry, true, EvictReasonExpired)
+		return false
+	}
+	return true
 }
 
 // Peek returns the key value (or undefined if not found) without updating
-// the "recently used"-ness of the key.
+// the "recently used"-ness of the key.  An expired entry is treated as
+// absent and is lazily evicted.
 func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
 	if i, ok := c.items[key]; ok {
-		return c.data[i].value, true
+		entry := &c.data[i]
+		if isExpired(entry, time.Now().UnixNano()) {
+			c.removeElement(i, *entry, true, EvictReasonExpired)
+			return value, false
+		}
+		return entry.value, true
 	}
 	return value, false
 }
@@ -200,17 +270,57 @@ func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
 // key was contained.
 func (c *LRU[K, V]) Remove(key K) (present bool) {
 	if i, ok := c.items[key]; ok {
-		c.removeElement(i, c.data[i], true)
+		c.removeElement(i, c.data[i], true, EvictReasonManual)
 		return true
 	}
 	return false
 }
 
+// SweepExpired scans up to maxEntries items in the cache and evicts any that
+// have expired.  It bounds the work done per call so it can be driven by a
+// periodic janitor without scanning the whole cache on every tick.
+func (c *LRU[K, V]) SweepExpired(maxEntries int) (evicted int) {
+	now := time.Now().UnixNano()
+	scanned := 0
+	for _, i := range c.items {
+		if scanned >= maxEntries {
+			break
+		}
+		scanned++
+		if entry := &c.data[i]; isExpired(entry, now) {
+			c.removeElement(i, *entry, true, EvictReasonExpired)
+			evicted++
+		}
+	}
+	return evicted
+}
+
 // Len returns the number of items in the cache.
 func (c *LRU[K, V]) Len() int {
 	return len(c.items)
 }
 
+// Ascending calls fn for each live entry in the cache in ascending recency
+// order (oldest first). Callers that re-add the entries in this order, such
+// as Snapshot/Restore, reconstruct the same relative recency without
+// needing to persist raw lastUsed counters.
+func (c *LRU[K, V]) Ascending(fn func(key K, value V)) {
+	order := make([]entry[K, V], 0, len(c.items))
+	for _, i := range c.items {
+		if ent := c.data[i]; ent.lastUsed > 0 {
+			order = append(order, ent)
+		}
+	}
+
+	slices.SortFunc(order, func(a, b entry[K, V]) bool {
+		return a.lastUsed < b.lastUsed
+	})
+
+	for _, ent := range order {
+		fn(ent.key, ent.value)
+	}
+}
+
 // Resize changes the cache size -- it is O(n * log(n)) expensive, and is best avoided.
 func (c *LRU[K, V]) Resize(size int) (evicted int) {
 	diff := c.Len() - size
@@ -236,7 +346,7 @@ func (c *LRU[K, V]) Resize(size int) (evicted int) {
 	oldSize := len(c.data)
 	for i := 0; i < diff; i++ {
 		j := oldSize - 1 - i
-		c.removeElement(j, c.data[j], true)
+		c.removeElement(j, c.data[j], true, EvictReasonCapacity)
 	}
 
 	c.size = int64(size)
@@ -251,18 +361,23 @@ func (c *LRU[K, V]) Resize(size int) (evicted int) {
 	return diff
 }
 
-// findOldest identifies an old item from the cache (approximately _the_ oldest).
+// findOldest identifies an old item from the cache (approximately _the_
+// oldest).  Expired entries in the probe set are preferred over unexpired
+// ones regardless of recency, since they are free to reclaim.
 func (c *LRU[K, V]) findOldest() (off int, ok bool) {
 	size := c.Len()
 	if size <= 0 {
 		return -1, false
 	}
 
+	now := time.Now().UnixNano()
+
 	// pick a random offset in our array of items to probe
 	base := c.rng.Intn(size)
 	oldestOff := base
 	// _copy_ the initial oldest onto the stack
 	var oldest entry[K, V] = c.data[base]
+	oldestExpired := isExpired(&oldest, now)
 
 	// if our offset does NOT result in us wrapping off the end of the array
 	// (which is very likely AND should be predicted well), don't require `% size`
@@ -272,18 +387,22 @@ func (c *LRU[K, V]) findOldest() (off int, ok bool) {
 		for j := 1; j < randomProbes; j++ {
 			off := base + j
 			candidate := &c.data[off]
-			if candidate.lastUsed < oldest.lastUsed {
-				oldestOff = off
-				oldest = *candidate
+			candidateExpired := isExpired(candidate, now)
+			if candidateExpired && !oldestExpired {
+				oldestOff, oldest, oldestExpired = off, *candidate, true
+			} else if candidateExpired == oldestExpired && candidate.lastUsed < oldest.lastUsed {
+				oldestOff, oldest = off, *candidate
 			}
 		}
 	} else {
 		for j := 1; j < randomProbes; j++ {
 			off := (base + j) % size
 			candidate := &c.data[off]
-			if candidate.lastUsed < oldest.lastUsed {
-				oldestOff = off
-				oldest = *candidate
+			candidateExpired := isExpired(candidate, now)
+			if candidateExpired && !oldestExpired {
+				oldestOff, oldest, oldestExpired = off, *candidate, true
+			} else if candidateExpired == oldestExpired && candidate.lastUsed < oldest.lastUsed {
+				oldestOff, oldest = off, *candidate
 			}
 		}
 	}
@@ -292,7 +411,7 @@ func (c *LRU[K, V]) findOldest() (off int, ok bool) {
 }
 
 // removeElement is used to remove a given list element from the cache
-func (c *LRU[K, V]) removeElement(i int, ent entry[K, V], doSwap bool) {
+func (c *LRU[K, V]) removeElement(i int, ent entry[K, V], doSwap bool, reason EvictReason) {
 	if int64(i) >= c.size || len(c.data) == 0 {
 		panic("invariant broken")
 	}
@@ -309,6 +428,6 @@ func (c *LRU[K, V]) removeElement(i int, ent entry[K, V], doSwap bool) {
 	delete(c.items, ent.key)
 
 	if c.onEvict != nil {
-		c.onEvict(ent.key, ent.value)
+		c.onEvict(ent.key, ent.value, reason)
 	}
 }
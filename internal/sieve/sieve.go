@@ -0,0 +1,193 @@
+// Package sieve implements a non-thread safe, fixed size cache using the
+// SIEVE eviction algorithm described in https://sieve-cache.com.
+package sieve
+
+import (
+	"container/list"
+	"errors"
+)
+
+// EvictCallback is used to get a callback when a cache entry is evicted
+type EvictCallback[K comparable, V any] func(key K, value V)
+
+// entry is used to hold a value in the list
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	visited bool
+}
+
+// Sieve implements a non-thread safe, fixed size cache using the SIEVE
+// eviction algorithm.  Unlike LRU, a cache hit does not move the entry
+// within the list -- it only flips a "visited" bit, which makes Get much
+// cheaper than a promoting LRU at the cost of a slightly coarser ordering.
+type Sieve[K comparable, V any] struct {
+	size    int
+	ll      *list.List
+	items   map[K]*list.Element
+	hand    *list.Element
+	onEvict EvictCallback[K, V]
+}
+
+// NewSieve constructs a Sieve of the given size.
+func NewSieve[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*Sieve[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("must provide a positive size")
+	}
+	c := &Sieve[K, V]{
+		size:    size,
+		ll:      list.New(),
+		items:   make(map[K]*list.Element, size),
+		onEvict: onEvict,
+	}
+	return c, nil
+}
+
+// Purge is used to completely clear the cache.
+func (c *Sieve[K, V]) Purge() {
+	if c.onEvict != nil {
+		for _, el := range c.items {
+			ent := el.Value.(*entry[K, V])
+			c.onEvict(ent.key, ent.value)
+		}
+	}
+	c.ll.Init()
+	c.items = make(map[K]*list.Element, c.size)
+	c.hand = nil
+}
+
+// Add adds a value to the cache.  Returns true if an eviction occurred.
+func (c *Sieve[K, V]) Add(key K, value V) (evicted bool) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		return false
+	}
+
+	if c.ll.Len() >= c.size {
+		c.evict()
+		evicted = true
+	}
+
+	el := c.ll.PushFront(&entry[K, V]{key: key, value: value})
+	c.items[key] = el
+
+	return
+}
+
+// Get looks up a key's value from the cache.  The entry's visited bit is
+// set, but it is not moved within the list.
+func (c *Sieve[K, V]) Get(key K) (value V, ok bool) {
+	if el, ok := c.items[key]; ok {
+		ent := el.Value.(*entry[K, V])
+		ent.visited = true
+		return ent.value, true
+	}
+	return
+}
+
+// Contains checks if a key is in the cache, without updating the recent-ness
+// or visited bit of the key.
+func (c *Sieve[K, V]) Contains(key K) (ok bool) {
+	_, ok = c.items[key]
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the visited bit of the key.
+func (c *Sieve[K, V]) Peek(key K) (value V, ok bool) {
+	if el, ok := c.items[key]; ok {
+		return el.Value.(*entry[K, V]).value, true
+	}
+	return
+}
+
+// Remove removes the provided key from the cache, returning if the key was
+// contained.
+func (c *Sieve[K, V]) Remove(key K) (present bool) {
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+		return true
+	}
+	return false
+}
+
+// Len returns the number of items in the cache.
+func (c *Sieve[K, V]) Len() int {
+	return c.ll.Len()
+}
+
+// Resize changes the cache size, evicting entries if it is shrinking.
+func (c *Sieve[K, V]) Resize(size int) (evicted int) {
+	// unlike NewSieve, which rejects a non-positive size, a negative size is
+	// clamped to zero (an empty cache) rather than looping forever trying to
+	// shrink past zero.
+	if size < 0 {
+		size = 0
+	}
+
+	for c.ll.Len() > size {
+		c.evict()
+		evicted++
+	}
+	c.size = size
+	return evicted
+}
+
+// evict runs the SIEVE hand: walk backward from the hand (wrapping around to
+// the tail when the front of the list is reached), clearing visited bits
+// until an unvisited entry is found, and evict it.  The hand is left
+// pointing at the entry just before the evicted one, towards the front
+// (the next newer entry), wrapping to the tail if the evicted entry was the
+// front.
+func (c *Sieve[K, V]) evict() {
+	o := c.hand
+	if o == nil {
+		o = c.ll.Back()
+	}
+
+	for o != nil && o.Value.(*entry[K, V]).visited {
+		o.Value.(*entry[K, V]).visited = false
+		o = c.prevOrWrap(o)
+	}
+
+	if o == nil {
+		return
+	}
+
+	// advance the hand before removing o, since removing it invalidates o's
+	// position in the list.
+	next := c.prevOrWrap(o)
+	if next == o {
+		next = nil
+	}
+	c.hand = next
+
+	c.removeElement(o)
+}
+
+// prevOrWrap returns the element before el, wrapping around to the tail of
+// the list when el is the head.
+func (c *Sieve[K, V]) prevOrWrap(el *list.Element) *list.Element {
+	if prev := el.Prev(); prev != nil {
+		return prev
+	}
+	return c.ll.Back()
+}
+
+// removeElement is used to remove a given list element from the cache
+func (c *Sieve[K, V]) removeElement(el *list.Element) {
+	if c.hand == el {
+		next := c.prevOrWrap(el)
+		if next == el {
+			next = nil
+		}
+		c.hand = next
+	}
+
+	c.ll.Remove(el)
+	ent := el.Value.(*entry[K, V])
+	delete(c.items, ent.key)
+	if c.onEvict != nil {
+		c.onEvict(ent.key, ent.value)
+	}
+}
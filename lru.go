@@ -8,26 +8,78 @@ import (
 
 // Cache is a thread-safe fixed size LRU cache.
 type Cache[K comparable, V any] struct {
-	lock sync.Mutex
-	lru  lru.LRU[K, V]
-	_    [16]byte
+	lock          sync.Mutex
+	lru           lru.LRU[K, V]
+	stats         *statsCounters
+	inflight      map[K]*call[V]
+	encode        func(any) ([]byte, error)
+	decode        func([]byte, any) error
+	janitorStop   chan struct{}
+	statsSinkStop chan struct{}
+	_             [16]byte
 }
 
 // New creates an LRU of the given size.
-func New[K comparable, V any](size int) *Cache[K, V] {
-	return NewWithEvict[K, V](size, nil)
+func New[K comparable, V any](size int, opts ...Option[K, V]) *Cache[K, V] {
+	return NewWithEvict[K, V](size, nil, opts...)
 }
 
 // NewWithEvict constructs a fixed size cache with the given eviction
 // callback.
-func NewWithEvict[K comparable, V any](size int, onEvicted func(key K, value V)) *Cache[K, V] {
-	lru, err := lru.NewLRU(size, onEvicted)
+func NewWithEvict[K comparable, V any](size int, onEvicted func(key K, value V), opts ...Option[K, V]) *Cache[K, V] {
+	var reasoned OnEvictedReason[K, V]
+	if onEvicted != nil {
+		reasoned = func(key K, value V, _ EvictReason) {
+			onEvicted(key, value)
+		}
+	}
+	return NewWithEvictReason[K, V](size, reasoned, opts...)
+}
+
+// NewWithEvictReason constructs a fixed size cache with the given eviction
+// callback, which is additionally told why the entry was evicted.
+func NewWithEvictReason[K comparable, V any](size int, onEvicted OnEvictedReason[K, V], opts ...Option[K, V]) *Cache[K, V] {
+	var o options[K, V]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Cache[K, V]{
+		stats: &statsCounters{},
+	}
+
+	// always wired in so eviction counters stay accurate even if the caller
+	// also registered their own callback.
+	combined := func(key K, value V, reason EvictReason) {
+		c.stats.recordEvict(reason)
+		if onEvicted != nil {
+			onEvicted(key, value, reason)
+		}
+	}
+
+	inner, err := lru.NewLRU(size, lru.EvictCallback[K, V](combined))
 	if err != nil {
 		panic(err)
 	}
-	c := &Cache[K, V]{
-		lru: *lru,
+	inner.SetDefaultTTL(o.defaultTTL)
+	c.lru = *inner
+
+	c.encode = o.encode
+	if c.encode == nil {
+		c.encode = gobEncode
+	}
+	c.decode = o.decode
+	if c.decode == nil {
+		c.decode = gobDecode
 	}
+
+	if o.janitorInterval > 0 {
+		c.startJanitor(o.janitorInterval)
+	}
+	if o.statsSink != nil {
+		c.startStatsSink(o.statsSink)
+	}
+
 	return c
 }
 
@@ -42,17 +94,21 @@ func (c *Cache[K, V]) Purge() {
 // Add adds a value to the cache. Returns true if an eviction occurred.
 func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	evicted = c.lru.Add(key, value)
+	c.lock.Unlock()
 
-	return c.lru.Add(key, value)
+	c.stats.recordInsert()
+	return evicted
 }
 
 // Get looks up a key's value from the cache.
 func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	value, ok = c.lru.Get(key)
+	c.lock.Unlock()
 
-	return c.lru.Get(key)
+	c.stats.recordLookup(ok)
+	return value, ok
 }
 
 // Contains checks if a key is in the cache, without updating the
@@ -78,13 +134,17 @@ func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
 // Returns whether found and whether an eviction occurred.
 func (c *Cache[K, V]) ContainsOrAdd(key K, value V) (ok, evicted bool) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	ok = c.lru.Contains(key)
+	if !ok {
+		evicted = c.lru.Add(key, value)
+	}
+	c.lock.Unlock()
 
-	if c.lru.Contains(key) {
-		return true, false
+	c.stats.recordLookup(ok)
+	if !ok {
+		c.stats.recordInsert()
 	}
-	evicted = c.lru.Add(key, value)
-	return false, evicted
+	return ok, evicted
 }
 
 // PeekOrAdd checks if a key is in the cache without updating the
@@ -92,15 +152,17 @@ func (c *Cache[K, V]) ContainsOrAdd(key K, value V) (ok, evicted bool) {
 // Returns whether found and whether an eviction occurred.
 func (c *Cache[K, V]) PeekOrAdd(key K, value V) (previous V, ok, evicted bool) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
-
 	previous, ok = c.lru.Peek(key)
-	if ok {
-		return previous, true, false
+	if !ok {
+		evicted = c.lru.Add(key, value)
 	}
+	c.lock.Unlock()
 
-	evicted = c.lru.Add(key, value)
-	return previous, false, evicted
+	c.stats.recordLookup(ok)
+	if !ok {
+		c.stats.recordInsert()
+	}
+	return previous, ok, evicted
 }
 
 // Remove removes the provided key from the cache.
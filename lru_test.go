@@ -18,3 +18,34 @@ func TestNew(t *testing.T) {
 		_ = v // use v
 	}
 }
+
+// TestContainsOrAddPeekOrAddRecordStats exercises that ContainsOrAdd and
+// PeekOrAdd feed the same hit/miss/insert counters as Get/Add, rather than
+// being invisible to Stats.
+func TestContainsOrAddPeekOrAddRecordStats(t *testing.T) {
+	l := New[string, int](128)
+
+	if ok, _ := l.ContainsOrAdd("a", 1); ok {
+		t.Fatalf("expected \"a\" to be missing on first ContainsOrAdd")
+	}
+	if ok, _ := l.ContainsOrAdd("a", 2); !ok {
+		t.Fatalf("expected \"a\" to be found on second ContainsOrAdd")
+	}
+	if _, ok, _ := l.PeekOrAdd("b", 1); ok {
+		t.Fatalf("expected \"b\" to be missing on first PeekOrAdd")
+	}
+	if _, ok, _ := l.PeekOrAdd("b", 2); !ok {
+		t.Fatalf("expected \"b\" to be found on second PeekOrAdd")
+	}
+
+	stats := l.Stats()
+	if stats.Misses != 2 {
+		t.Fatalf("Misses = %d, want 2", stats.Misses)
+	}
+	if stats.Hits != 2 {
+		t.Fatalf("Hits = %d, want 2", stats.Hits)
+	}
+	if stats.Inserts != 2 {
+		t.Fatalf("Inserts = %d, want 2", stats.Inserts)
+	}
+}
@@ -0,0 +1,161 @@
+package lru
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// Hasher computes a hash for a key, used to pick the shard that holds it.
+type Hasher[K comparable] func(key K) uint64
+
+// shardedOptions holds the settings accumulated from a NewSharded Option list.
+type shardedOptions[K comparable, V any] struct {
+	hasher Hasher[K]
+}
+
+// ShardedOption configures optional ShardedCache behavior passed to
+// NewSharded.
+type ShardedOption[K comparable, V any] func(*shardedOptions[K, V])
+
+// WithHasher supplies a Hasher to use instead of the default maphash-based
+// one.  The default only knows how to hash string keys; non-string keys
+// must supply a Hasher, or the ShardedCache panics on the first call that
+// takes a key (Add, Get, Peek, Contains, Remove, ...).
+func WithHasher[K comparable, V any](h Hasher[K]) ShardedOption[K, V] {
+	return func(o *shardedOptions[K, V]) {
+		o.hasher = h
+	}
+}
+
+// ShardedCache partitions the key space across N independent Cache
+// instances, each with its own lock, so that concurrent callers touching
+// different shards don't serialize on a single global lock the way they do
+// with Cache.
+type ShardedCache[K comparable, V any] struct {
+	shards []*Cache[K, V]
+	hasher Hasher[K]
+	seed   maphash.Seed
+}
+
+// NewSharded creates a ShardedCache of the given total size, split evenly
+// across the given number of shards.
+func NewSharded[K comparable, V any](size, shards int, opts ...ShardedOption[K, V]) *ShardedCache[K, V] {
+	if size <= 0 {
+		panic("must provide a positive size")
+	}
+	if shards <= 0 {
+		panic("must provide a positive number of shards")
+	}
+
+	var o shardedOptions[K, V]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &ShardedCache[K, V]{
+		shards: make([]*Cache[K, V], shards),
+		hasher: o.hasher,
+		seed:   maphash.MakeSeed(),
+	}
+	if c.hasher == nil {
+		c.hasher = c.defaultHash
+	}
+
+	shardSize := size / shards
+	if shardSize <= 0 {
+		shardSize = 1
+	}
+	for i := range c.shards {
+		c.shards[i] = New[K, V](shardSize)
+	}
+
+	return c
+}
+
+// defaultHash hashes string keys via maphash.  Non-string keys have no
+// default and must be used with WithHasher.
+func (c *ShardedCache[K, V]) defaultHash(key K) uint64 {
+	s, ok := any(key).(string)
+	if !ok {
+		panic(fmt.Sprintf("lru: no default Hasher for key type %T; construct with WithHasher", key))
+	}
+
+	var h maphash.Hash
+	h.SetSeed(c.seed)
+	h.WriteString(s)
+	return h.Sum64()
+}
+
+func (c *ShardedCache[K, V]) shardFor(key K) *Cache[K, V] {
+	return c.shards[c.hasher(key)%uint64(len(c.shards))]
+}
+
+// Add adds a value to the cache. Returns true if an eviction occurred.
+func (c *ShardedCache[K, V]) Add(key K, value V) (evicted bool) {
+	return c.shardFor(key).Add(key, value)
+}
+
+// Get looks up a key's value from the cache.
+func (c *ShardedCache[K, V]) Get(key K) (value V, ok bool) {
+	return c.shardFor(key).Get(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness or deleting it for being stale.
+func (c *ShardedCache[K, V]) Contains(key K) bool {
+	return c.shardFor(key).Contains(key)
+}
+
+// Peek returns the key value (or undefined if not found) without updating
+// the "recently used"-ness of the key.
+func (c *ShardedCache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.shardFor(key).Peek(key)
+}
+
+// ContainsOrAdd checks if a key is in the cache without updating the
+// recent-ness or deleting it for being stale, and if not, adds the value.
+// Returns whether found and whether an eviction occurred.
+func (c *ShardedCache[K, V]) ContainsOrAdd(key K, value V) (ok, evicted bool) {
+	return c.shardFor(key).ContainsOrAdd(key, value)
+}
+
+// PeekOrAdd checks if a key is in the cache without updating the
+// recent-ness or deleting it for being stale, and if not, adds the value.
+// Returns whether found and whether an eviction occurred.
+func (c *ShardedCache[K, V]) PeekOrAdd(key K, value V) (previous V, ok, evicted bool) {
+	return c.shardFor(key).PeekOrAdd(key, value)
+}
+
+// Remove removes the provided key from the cache.
+func (c *ShardedCache[K, V]) Remove(key K) (present bool) {
+	return c.shardFor(key).Remove(key)
+}
+
+// Purge is used to completely clear the cache.
+func (c *ShardedCache[K, V]) Purge() {
+	for _, s := range c.shards {
+		s.Purge()
+	}
+}
+
+// Len returns the number of items in the cache, summed across shards.
+func (c *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, s := range c.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Resize changes the total cache size, redistributing capacity evenly
+// across shards.
+func (c *ShardedCache[K, V]) Resize(size int) (evicted int) {
+	shardSize := size / len(c.shards)
+	if shardSize <= 0 {
+		shardSize = 1
+	}
+	for _, s := range c.shards {
+		evicted += s.Resize(shardSize)
+	}
+	return evicted
+}
@@ -0,0 +1,113 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// maxSnapshotRecordSize bounds how large a single record's encoded length may
+// claim to be, so a corrupted or truncated snapshot can't make Restore try
+// to allocate an unreasonable amount of memory.
+const maxSnapshotRecordSize = 64 << 20 // 64 MiB
+
+// WithCodec overrides the per-entry encoding used by Snapshot and Restore,
+// which defaults to encoding/gob. enc/dec are each called once per cache
+// entry, not once for the whole snapshot, so callers can plug in JSON,
+// msgpack, or any other format that round-trips a single record.
+func WithCodec[K comparable, V any](enc func(any) ([]byte, error), dec func([]byte, any) error) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.encode = enc
+		o.decode = dec
+	}
+}
+
+func gobEncode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(b []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+}
+
+// snapshotRecord is the unit Snapshot/Restore encode per cache entry.
+type snapshotRecord[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Snapshot writes the cache's current items to w in ascending recency order
+// (oldest first), so that Restore can reconstruct the same relative
+// recency by re-adding them in the same order. TTLs are not persisted --
+// restored entries are re-added with Add, so they expire only if the
+// options passed to Restore set a default TTL.
+func (c *Cache[K, V]) Snapshot(w io.Writer) error {
+	c.lock.Lock()
+	records := make([]snapshotRecord[K, V], 0, c.lru.Len())
+	c.lru.Ascending(func(key K, value V) {
+		records = append(records, snapshotRecord[K, V]{Key: key, Value: value})
+	})
+	enc := c.encode
+	c.lock.Unlock()
+
+	if err := binary.Write(w, binary.LittleEndian, int64(len(records))); err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		b, err := enc(rec)
+		if err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, int64(len(b))); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore reconstructs a cache of the given size from a Snapshot, re-adding
+// entries in the order Snapshot wrote them so the restored cache's relative
+// recency matches the original. This lets a long-lived service warm its
+// cache across restarts instead of paying a cold-start penalty.
+func Restore[K comparable, V any](r io.Reader, size int, opts ...Option[K, V]) (*Cache[K, V], error) {
+	c := New[K, V](size, opts...)
+
+	var n int64
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+
+	for i := int64(0); i < n; i++ {
+		var length int64
+		if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+			return nil, err
+		}
+		if length < 0 || length > maxSnapshotRecordSize {
+			return nil, fmt.Errorf("lru: corrupt snapshot: invalid record length %d", length)
+		}
+
+		b := make([]byte, length)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		var rec snapshotRecord[K, V]
+		if err := c.decode(b, &rec); err != nil {
+			return nil, err
+		}
+		c.Add(rec.Key, rec.Value)
+	}
+
+	return c, nil
+}
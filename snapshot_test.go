@@ -0,0 +1,43 @@
+package lru
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	l := New[string, int](4)
+	for i := 0; i < 4; i++ {
+		l.Add([]string{"a", "b", "c", "d"}[i], i)
+	}
+
+	var buf bytes.Buffer
+	if err := l.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	restored, err := Restore[string, int](&buf, 4)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	if restored.Len() != l.Len() {
+		t.Fatalf("restored len = %d, want %d", restored.Len(), l.Len())
+	}
+	if v, ok := restored.Get("c"); !ok || v != 2 {
+		t.Fatalf("restored Get(c) = (%v, %v), want (2, true)", v, ok)
+	}
+}
+
+// TestRestoreRejectsCorruptLength ensures a corrupted (e.g. negative) record
+// length is reported as an error rather than panicking make([]byte, ...).
+func TestRestoreRejectsCorruptLength(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, int64(1))  // one record
+	binary.Write(&buf, binary.LittleEndian, int64(-1)) // corrupt length
+
+	if _, err := Restore[string, int](&buf, 4); err == nil {
+		t.Fatalf("expected Restore to return an error for a negative record length")
+	}
+}
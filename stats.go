@@ -0,0 +1,130 @@
+package lru
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is an atomic snapshot of a Cache's hit/miss/eviction counters.
+type Stats struct {
+	Hits        int64
+	Misses      int64
+	Evictions   int64
+	Expirations int64
+	Inserts     int64
+	Removals    int64
+}
+
+// HitRatio returns Hits / (Hits + Misses), or 0 if there have been no
+// lookups yet.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// statsCounters holds the counters backing Cache.Stats.  Every field is
+// updated with sync/atomic rather than guarded by c.lock, so reading Stats
+// never takes c.lock and never contends with Get/Add.  Lookup and insert
+// counters are bumped after c.lock is released; eviction, expiration and
+// removal counters are bumped from the eviction callback, which runs while
+// c.lock is held.
+type statsCounters struct {
+	hits        int64
+	misses      int64
+	evictions   int64
+	expirations int64
+	inserts     int64
+	removals    int64
+}
+
+func (s *statsCounters) recordLookup(hit bool) {
+	if hit {
+		atomic.AddInt64(&s.hits, 1)
+	} else {
+		atomic.AddInt64(&s.misses, 1)
+	}
+}
+
+func (s *statsCounters) recordInsert() {
+	atomic.AddInt64(&s.inserts, 1)
+}
+
+func (s *statsCounters) recordEvict(reason EvictReason) {
+	switch reason {
+	case EvictReasonCapacity:
+		atomic.AddInt64(&s.evictions, 1)
+	case EvictReasonExpired:
+		atomic.AddInt64(&s.expirations, 1)
+	case EvictReasonManual:
+		atomic.AddInt64(&s.removals, 1)
+	}
+}
+
+func (s *statsCounters) snapshot() Stats {
+	return Stats{
+		Hits:        atomic.LoadInt64(&s.hits),
+		Misses:      atomic.LoadInt64(&s.misses),
+		Evictions:   atomic.LoadInt64(&s.evictions),
+		Expirations: atomic.LoadInt64(&s.expirations),
+		Inserts:     atomic.LoadInt64(&s.inserts),
+		Removals:    atomic.LoadInt64(&s.removals),
+	}
+}
+
+func (s *statsCounters) reset() {
+	atomic.StoreInt64(&s.hits, 0)
+	atomic.StoreInt64(&s.misses, 0)
+	atomic.StoreInt64(&s.evictions, 0)
+	atomic.StoreInt64(&s.expirations, 0)
+	atomic.StoreInt64(&s.inserts, 0)
+	atomic.StoreInt64(&s.removals, 0)
+}
+
+// statsSinkInterval is how often the goroutine started by WithStatsSink
+// calls the sink with a fresh Stats snapshot.
+const statsSinkInterval = 10 * time.Second
+
+// WithStatsSink starts a background goroutine that calls sink with a Stats
+// snapshot every statsSinkInterval, for periodic export to a metrics
+// system. The goroutine stops when Close is called.
+func WithStatsSink[K comparable, V any](sink func(Stats)) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.statsSink = sink
+	}
+}
+
+// Stats returns an atomic snapshot of the cache's hit/miss/eviction
+// counters.
+func (c *Cache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// Reset zeroes the cache's stats counters.
+func (c *Cache[K, V]) Reset() {
+	c.stats.reset()
+}
+
+// startStatsSink launches the background export goroutine; it is only
+// called while constructing the cache, so no synchronization is needed
+// around c.statsSinkStop itself.
+func (c *Cache[K, V]) startStatsSink(sink func(Stats)) {
+	c.statsSinkStop = make(chan struct{})
+	stop := c.statsSinkStop
+
+	go func() {
+		ticker := time.NewTicker(statsSinkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				sink(c.stats.snapshot())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,118 @@
+package lru
+
+import (
+	"time"
+
+	"github.com/greyireland/lru/internal/lru"
+)
+
+// EvictReason indicates why an entry was removed from the cache.
+type EvictReason = lru.EvictReason
+
+const (
+	// EvictReasonCapacity indicates the entry was evicted to make room for a
+	// new one.
+	EvictReasonCapacity = lru.EvictReasonCapacity
+	// EvictReasonExpired indicates the entry's TTL had elapsed.
+	EvictReasonExpired = lru.EvictReasonExpired
+	// EvictReasonManual indicates the entry was removed via Remove or Purge.
+	EvictReasonManual = lru.EvictReasonManual
+)
+
+// OnEvictedReason is used to get a callback when a cache entry is evicted,
+// along with the reason it was evicted.  It supersedes the plain
+// func(key K, value V) callback accepted by NewWithEvict, which is kept for
+// backward compatibility and simply ignores the reason.
+type OnEvictedReason[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// options holds the settings accumulated from a New/NewWithEvict Option list.
+type options[K comparable, V any] struct {
+	defaultTTL      time.Duration
+	janitorInterval time.Duration
+	statsSink       func(Stats)
+	encode          func(any) ([]byte, error)
+	decode          func([]byte, any) error
+}
+
+// Option configures optional cache behavior passed to New or NewWithEvict.
+type Option[K comparable, V any] func(*options[K, V])
+
+// WithDefaultTTL sets the TTL applied to entries added via Add.  It has no
+// effect on entries added via AddWithTTL, which always specify their own
+// TTL. The default is no expiry.
+func WithDefaultTTL[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.defaultTTL = d
+	}
+}
+
+// WithJanitor starts a background goroutine that, every interval, scans a
+// bounded number of entries and evicts any that have expired.  Without it,
+// an expired entry that is never looked up again sits in the cache (and
+// counts against its capacity) until it is evicted lazily or the cache fills
+// up. The janitor goroutine runs until Close is called; it is not stopped by
+// garbage collection, so a cache using WithJanitor must be Closed to avoid
+// leaking the goroutine.
+func WithJanitor[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(o *options[K, V]) {
+		o.janitorInterval = interval
+	}
+}
+
+// janitorScanSize bounds how many entries the janitor inspects per tick.
+const janitorScanSize = 64
+
+// AddWithTTL adds a value to the cache that expires after ttl, overriding
+// any default TTL set with WithDefaultTTL.  A zero ttl means the entry never
+// expires.  Returns true if an eviction occurred.
+func (c *Cache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	c.lock.Lock()
+	evicted = c.lru.AddWithTTL(key, value, ttl)
+	c.lock.Unlock()
+
+	c.stats.recordInsert()
+	return evicted
+}
+
+// startJanitor launches the background sweep goroutine; it is only called
+// while constructing the cache, so no synchronization is needed around
+// c.janitorStop itself.
+func (c *Cache[K, V]) startJanitor(interval time.Duration) {
+	c.janitorStop = make(chan struct{})
+	stop := c.janitorStop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.lock.Lock()
+				c.lru.SweepExpired(janitorScanSize)
+				c.lock.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the background goroutines started by WithJanitor and
+// WithStatsSink, if any.  It is a no-op for a cache constructed without
+// either, and is safe to call more than once.
+func (c *Cache[K, V]) Close() {
+	c.lock.Lock()
+	janitorStop := c.janitorStop
+	c.janitorStop = nil
+	statsSinkStop := c.statsSinkStop
+	c.statsSinkStop = nil
+	c.lock.Unlock()
+
+	if janitorStop != nil {
+		close(janitorStop)
+	}
+	if statsSinkStop != nil {
+		close(statsSinkStop)
+	}
+}
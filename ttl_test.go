@@ -0,0 +1,20 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddWithTTL(t *testing.T) {
+	l := New[string, int](128)
+
+	l.AddWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := l.Get("a"); ok {
+		t.Fatalf("expected expired entry to be absent")
+	}
+	if l.Len() != 0 {
+		t.Fatalf("expected expired entry to be evicted, got len %d", l.Len())
+	}
+}